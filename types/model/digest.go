@@ -0,0 +1,163 @@
+package model
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"regexp"
+
+	"lukechampine.com/blake3"
+)
+
+// Errors returned by [ParseDigestErr].
+var (
+	// ErrDigestUnsupported is returned when a digest names an algorithm
+	// that is not registered.
+	ErrDigestUnsupported = errors.New("unsupported digest algorithm")
+
+	// ErrDigestInvalidLength is returned when a digest's hex-encoded hash
+	// is not the length expected for its algorithm.
+	ErrDigestInvalidLength = errors.New("invalid digest length")
+
+	// ErrDigestInvalidFormat is returned when a digest string cannot be
+	// split into an algorithm and hash, or the hash is not valid hex.
+	ErrDigestInvalidFormat = errors.New("invalid digest format")
+)
+
+// digestAlgorithm describes a hash algorithm that can be used in a
+// [Digest].
+type digestAlgorithm struct {
+	// name is the canonical, lowercase name of the algorithm as it
+	// appears in a digest string (e.g. "sha256").
+	name string
+
+	// hexLen is the length, in hex characters, of a fully encoded hash
+	// for this algorithm.
+	hexLen int
+
+	// New returns a new hash.Hash that computes this algorithm's sum.
+	New func() hash.Hash
+}
+
+// digestAlgorithms is the table of algorithms recognized by
+// [ParseDigestErr] and [Digest.Verify].
+var digestAlgorithms = map[string]digestAlgorithm{
+	"sha256": {name: "sha256", hexLen: 64, New: sha256.New},
+	"sha512": {name: "sha512", hexLen: 128, New: sha512.New},
+	"sha384": {name: "sha384", hexLen: 96, New: sha512.New384},
+	"blake3": {name: "blake3", hexLen: 64, New: func() hash.Hash { return blake3.New(32, nil) }},
+}
+
+var hexPattern = regexp.MustCompile(`^[a-f0-9]+$`)
+
+// Digest represents the digest of a model's blob: an algorithm name paired
+// with the hash it produced. Unlike the old fixed-size sha256-only Digest,
+// its Hash is variable-length, so Digest is no longer comparable with ==;
+// use [Digest.String] or compare Hash with [bytes.Equal] instead.
+type Digest struct {
+	Algorithm string
+	Hash      []byte
+}
+
+// IsValid returns true if the digest has a registered Algorithm and a Hash
+// of the length expected for that algorithm that is not all zeros.
+func (d Digest) IsValid() bool {
+	a, ok := digestAlgorithms[d.Algorithm]
+	if !ok {
+		return false
+	}
+	if len(d.Hash) != a.hexLen/2 {
+		return false
+	}
+	return !bytes.Equal(d.Hash, make([]byte, len(d.Hash)))
+}
+
+// String returns the digest as a string in the form "algorithm-hash". The
+// hash is encoded as a hex string. It returns the empty string if d has no
+// Algorithm.
+func (d Digest) String() string {
+	if d.Algorithm == "" {
+		return ""
+	}
+	return d.Algorithm + "-" + hex.EncodeToString(d.Hash)
+}
+
+// LogValue returns a slog.Value that represents the digest as a string.
+func (d Digest) LogValue() slog.Value {
+	return slog.StringValue(d.String())
+}
+
+// ParseDigestErr parses a digest string into a Digest. It accepts both of
+// the forms:
+//
+//	sha256:deadbeef
+//	sha256-deadbeef
+//
+// and any other algorithm registered in the internal algorithm table (see
+// [Digest.Verify]). The hash part must be the hex-encoded length expected
+// for the named algorithm.
+//
+// The form "algorithm:hash" does not round trip through [Digest.String].
+func ParseDigestErr(s string) (Digest, error) {
+	typ, h, ok := cutLast(s, ":")
+	if !ok {
+		typ, h, ok = cutLast(s, "-")
+		if !ok {
+			return Digest{}, fmt.Errorf("%w: %q is missing an algorithm separator", ErrDigestInvalidFormat, s)
+		}
+	}
+
+	a, ok := digestAlgorithms[typ]
+	if !ok {
+		return Digest{}, fmt.Errorf("%w: %q", ErrDigestUnsupported, typ)
+	}
+	if len(h) != a.hexLen {
+		return Digest{}, fmt.Errorf("%w: %s digest must be %d hex characters, got %d", ErrDigestInvalidLength, a.name, a.hexLen, len(h))
+	}
+	if !hexPattern.MatchString(h) {
+		return Digest{}, fmt.Errorf("%w: %q is not lowercase hex", ErrDigestInvalidFormat, h)
+	}
+
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %v", ErrDigestInvalidFormat, err)
+	}
+	return Digest{Algorithm: a.name, Hash: b}, nil
+}
+
+// ParseDigest parses s into a Digest, discarding any error. Callers that
+// need to distinguish why s failed to parse should use [ParseDigestErr]
+// instead.
+func ParseDigest(s string) Digest {
+	d, err := ParseDigestErr(s)
+	if err != nil {
+		return Digest{}
+	}
+	return d
+}
+
+// Verify streams r through the hash function registered for d's Algorithm
+// and returns an error if the resulting sum does not match d, or if d's
+// Algorithm is not registered.
+func (d Digest) Verify(r io.Reader) error {
+	a, ok := digestAlgorithms[d.Algorithm]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrDigestUnsupported, d.Algorithm)
+	}
+	h := a.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	sum := h.Sum(nil)
+	if !bytes.Equal(sum, d.Hash) {
+		got := Digest{Algorithm: a.name, Hash: sum}
+		return fmt.Errorf("digest mismatch: have %s, want %s", got, d)
+	}
+	return nil
+}
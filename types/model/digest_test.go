@@ -0,0 +1,107 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	validSHA512Hex = "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789"
+	validSHA384Hex = "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789"
+	validBlake3Hex = "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789"
+)
+
+func TestParseDigest(t *testing.T) {
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{in: "sha256:" + validSHA256Hex, want: true},
+		{in: "sha256-" + validSHA256Hex, want: true},
+		{in: "sha512-" + validSHA512Hex, want: true},
+		{in: "sha384-" + validSHA384Hex, want: true},
+		{in: "blake3-" + validBlake3Hex, want: true},
+
+		{in: "", want: false},
+		{in: "sha134:" + validSHA256Hex, want: false},
+		{in: "sha256:" + validSHA256Hex + "x", want: false},
+		{in: "sha256:x" + validSHA256Hex, want: false},
+		{in: "sha256-" + validSHA256Hex + "x", want: false},
+		{in: "sha256-x", want: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			d := ParseDigest(tt.in)
+			if d.IsValid() != tt.want {
+				t.Errorf("ParseDigest(%q).IsValid() = %v; want %v", tt.in, d.IsValid(), tt.want)
+			}
+			norm := strings.ReplaceAll(tt.in, ":", "-")
+			if d.IsValid() && d.String() != norm {
+				t.Errorf("ParseDigest(%q).String() = %q; want %q", tt.in, d.String(), norm)
+			}
+		})
+	}
+}
+
+func TestParseDigestErr(t *testing.T) {
+	cases := []struct {
+		in      string
+		wantErr error
+	}{
+		{in: "sha256:" + validSHA256Hex, wantErr: nil},
+		{in: "sha134:" + validSHA256Hex, wantErr: ErrDigestUnsupported},
+		{in: "sha256:" + validSHA256Hex + "x", wantErr: ErrDigestInvalidLength},
+		{in: "sha256:x" + validSHA256Hex[1:], wantErr: ErrDigestInvalidFormat},
+		{in: "", wantErr: ErrDigestInvalidFormat},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParseDigestErr(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseDigestErr(%q) = %v; want %v", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDigestString(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: "sha256:" + validSHA256Hex, want: "sha256-" + validSHA256Hex},
+		{in: "sha256-" + validSHA256Hex, want: "sha256-" + validSHA256Hex},
+		{in: "", want: ""},
+		{in: "blah-100000000000000000000000000000000000000000000000000000000000000", want: ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			d := ParseDigest(tt.in)
+			if d.String() != tt.want {
+				t.Errorf("ParseDigest(%q).String() = %q; want %q", tt.in, d.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestVerify(t *testing.T) {
+	const content = "hello, ollama"
+
+	sum := sha256.Sum256([]byte(content))
+	d, err := ParseDigestErr("sha256-" + hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Verify(strings.NewReader(content)); err != nil {
+		t.Errorf("Verify() = %v; want nil", err)
+	}
+	if err := d.Verify(strings.NewReader("wrong content")); err == nil {
+		t.Errorf("Verify() = nil; want error")
+	}
+}
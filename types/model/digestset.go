@@ -0,0 +1,117 @@
+package model
+
+import (
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Errors returned by [DigestSet.Lookup].
+var (
+	// ErrDigestNotFound is returned when no digest in the set has the
+	// given prefix.
+	ErrDigestNotFound = errors.New("digest not found")
+
+	// ErrDigestAmbiguous is returned when two or more digests in the set
+	// share the given prefix.
+	ErrDigestAmbiguous = errors.New("ambiguous digest prefix")
+)
+
+// DigestSet is a set of [Digest] values indexed for lookup by a short,
+// unambiguous hex prefix of their hash, the way a user might type
+// "ollama run abc123".
+//
+// The zero value is an empty set, ready to use.
+type DigestSet struct {
+	// hexes holds the hex-encoded hash of every digest in the set, kept
+	// sorted so that digests sharing a prefix are adjacent to each
+	// other; Lookup and ShortestUnique binary search it for the
+	// prefix's lower bound rather than scanning it.
+	hexes   []string
+	digests map[string]Digest
+}
+
+// Add inserts d into the set. Adding a digest already in the set is a
+// no-op.
+func (s *DigestSet) Add(d Digest) {
+	h := hex.EncodeToString(d.Hash)
+	if _, ok := s.digests[h]; ok {
+		return
+	}
+	if s.digests == nil {
+		s.digests = make(map[string]Digest)
+	}
+	s.digests[h] = d
+
+	i := sort.SearchStrings(s.hexes, h)
+	s.hexes = append(s.hexes, "")
+	copy(s.hexes[i+1:], s.hexes[i:])
+	s.hexes[i] = h
+}
+
+// Remove deletes d from the set, if present.
+func (s *DigestSet) Remove(d Digest) {
+	h := hex.EncodeToString(d.Hash)
+	if _, ok := s.digests[h]; !ok {
+		return
+	}
+	delete(s.digests, h)
+
+	i := sort.SearchStrings(s.hexes, h)
+	s.hexes = append(s.hexes[:i], s.hexes[i+1:]...)
+}
+
+// Lookup returns the single digest in the set whose hash starts with the
+// hex prefix encoded in short. The prefix may be given as bare hex, or
+// prefixed with an algorithm name and "-" or ":" (e.g. "sha256-abc",
+// "sha256:abc"), and is matched case-insensitively.
+//
+// It returns [ErrDigestNotFound] if no digest matches, and
+// [ErrDigestAmbiguous] if more than one does.
+func (s *DigestSet) Lookup(short string) (Digest, error) {
+	prefix := strings.ToLower(trimDigestPrefix(short))
+
+	i := sort.SearchStrings(s.hexes, prefix)
+	if i == len(s.hexes) || !strings.HasPrefix(s.hexes[i], prefix) {
+		return Digest{}, ErrDigestNotFound
+	}
+	if i+1 < len(s.hexes) && strings.HasPrefix(s.hexes[i+1], prefix) {
+		return Digest{}, ErrDigestAmbiguous
+	}
+	return s.digests[s.hexes[i]], nil
+}
+
+// ShortestUnique returns the shortest prefix of d's hash that uniquely
+// identifies it within the set. It panics if d is not in the set.
+func (s *DigestSet) ShortestUnique(d Digest) string {
+	h := hex.EncodeToString(d.Hash)
+	i := sort.SearchStrings(s.hexes, h)
+	if i == len(s.hexes) || s.hexes[i] != h {
+		panic("model: ShortestUnique of digest not in set")
+	}
+
+	for n := 1; n <= len(h); n++ {
+		prefix := h[:n]
+		if i > 0 && strings.HasPrefix(s.hexes[i-1], prefix) {
+			continue
+		}
+		if i+1 < len(s.hexes) && strings.HasPrefix(s.hexes[i+1], prefix) {
+			continue
+		}
+		return prefix
+	}
+	return h
+}
+
+// trimDigestPrefix strips a leading "<algorithm>-" or "<algorithm>:" from
+// s, if present, leaving the bare hex hash.
+func trimDigestPrefix(s string) string {
+	if _, hash, ok := cutLast(s, "-"); ok {
+		return hash
+	}
+	if _, hash, ok := cutLast(s, ":"); ok {
+		return hash
+	}
+	return s
+}
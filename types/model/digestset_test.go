@@ -0,0 +1,128 @@
+package model
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func digestFromHex(h string) Digest {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		panic(err)
+	}
+	return Digest{Algorithm: "sha256", Hash: b}
+}
+
+func digestsEqual(a, b Digest) bool {
+	return a.Algorithm == b.Algorithm && bytes.Equal(a.Hash, b.Hash)
+}
+
+func TestDigestSetLookup(t *testing.T) {
+	var s DigestSet
+	d1 := digestFromHex("aaaaaaaa")
+	d2 := digestFromHex("aaaabbbb")
+	d3 := digestFromHex("cccccccc")
+	s.Add(d1)
+	s.Add(d2)
+	s.Add(d3)
+
+	cases := []struct {
+		short   string
+		want    Digest
+		wantErr error
+	}{
+		{short: "cccc", want: d3},
+		{short: "CCCC", want: d3},
+		{short: "sha256-cccc", want: d3},
+		{short: "sha256:cccc", want: d3},
+		{short: "aaaabbbb", want: d2},
+		{short: "aaaa", wantErr: ErrDigestAmbiguous},
+		{short: "dddd", wantErr: ErrDigestNotFound},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.short, func(t *testing.T) {
+			got, err := s.Lookup(tt.short)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Lookup(%q) error = %v; want %v", tt.short, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lookup(%q) unexpected error: %v", tt.short, err)
+			}
+			if !digestsEqual(got, tt.want) {
+				t.Errorf("Lookup(%q) = %v; want %v", tt.short, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestSetRemove(t *testing.T) {
+	var s DigestSet
+	d := digestFromHex("aaaaaaaa")
+	s.Add(d)
+	s.Remove(d)
+	if _, err := s.Lookup("aaaa"); !errors.Is(err, ErrDigestNotFound) {
+		t.Errorf("Lookup after Remove = %v; want ErrDigestNotFound", err)
+	}
+}
+
+func TestDigestSetShortestUnique(t *testing.T) {
+	var s DigestSet
+	d1 := digestFromHex("aaaaaaaa")
+	d2 := digestFromHex("aaaabbbb")
+	s.Add(d1)
+	s.Add(d2)
+
+	short := s.ShortestUnique(d1)
+	got, err := s.Lookup(short)
+	if err != nil {
+		t.Fatalf("Lookup(%q) = %v", short, err)
+	}
+	if !digestsEqual(got, d1) {
+		t.Errorf("Lookup(ShortestUnique(d1)) = %v; want %v", got, d1)
+	}
+}
+
+func FuzzDigestSetShortestUnique(f *testing.F) {
+	f.Add(int64(1), 8)
+	f.Add(int64(42), 32)
+	f.Fuzz(func(t *testing.T, seed int64, n int) {
+		if n <= 0 || n > 64 {
+			t.Skip()
+		}
+
+		r := rand.New(rand.NewSource(seed))
+		var s DigestSet
+		var digests []Digest
+		seen := make(map[string]bool)
+		for len(digests) < n {
+			b := make([]byte, 32)
+			r.Read(b)
+			h := hex.EncodeToString(b)
+			if seen[h] {
+				continue
+			}
+			seen[h] = true
+			d := Digest{Algorithm: "sha256", Hash: b}
+			digests = append(digests, d)
+			s.Add(d)
+		}
+
+		for _, d := range digests {
+			short := s.ShortestUnique(d)
+			got, err := s.Lookup(short)
+			if err != nil {
+				t.Fatalf("Lookup(%q) = %v", short, err)
+			}
+			if !digestsEqual(got, d) {
+				t.Fatalf("Lookup(ShortestUnique(d)) = %v; want %v", got, d)
+			}
+		}
+	})
+}
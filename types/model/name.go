@@ -4,7 +4,6 @@ package model
 
 import (
 	"cmp"
-	"encoding/hex"
 	"hash/maphash"
 	"log/slog"
 	"strings"
@@ -110,32 +109,9 @@ func ParseName(s string) Name {
 //
 // The name returned is not guaranteed to be valid. If it is not valid, the
 // field values are left in an undefined state. Use [Name.IsValid] to check
-// if the name is valid.
+// if the name is valid, or [ParseNameErr] to learn why it is not.
 func ParseNameNoDefaults(s string) Name {
-	var n Name
-	var promised bool
-
-	// Digest is the exception to the rule that both parts separated by a
-	// separator must be present. If the digest is promised, the digest
-	// part must be present, but the name part can be empty/undefined.
-	s, n.rawDigest, promised = cutLast(s, "@")
-	if promised && n.rawDigest == "" {
-		n.rawDigest = MissingPart
-	}
-
-	s, n.tag, _ = cutPromised(s, ":")
-	s, n.model, promised = cutPromised(s, "/")
-	if !promised {
-		n.model = s
-		return n
-	}
-	s, n.namespace, promised = cutPromised(s, "/")
-	if !promised {
-		n.namespace = s
-		return n
-	}
-	n.host = s
-
+	n, _ := parseNameOffsets(s)
 	return n
 }
 
@@ -199,22 +175,8 @@ func (n Name) Equal(o Name) bool {
 //   - The tag part MUST have a length in the range [1,80], start with an
 //     alphanumeric character, and contain only [A-Za-z0-9._-] characters.
 func (n Name) IsValid() bool {
-	if n.model == "" && n.rawDigest == "" {
-		return false
-	}
-	var parts = [...]string{
-		n.host,
-		n.namespace,
-		n.model,
-		n.tag,
-		n.rawDigest,
-	}
-	for i, part := range parts {
-		if part != "" && !isValidPart(partKind(i), part) {
-			return false
-		}
-	}
-	return true
+	_, _, _, ok := checkName(n)
+	return ok
 }
 
 // String returns a formated string representation of the name, if n is
@@ -266,25 +228,38 @@ func (n Name) Merge(o Name) Name {
 	return n
 }
 
-func isValidLen(kind partKind, s string) bool {
+// partLenBounds returns the minimum and maximum valid length for a part of
+// the given kind.
+func partLenBounds(kind partKind) (min, max int) {
 	switch kind {
 	case kindHost:
-		return len(s) >= 1 && len(s) <= 350
+		return 1, 350
 	case kindTag:
-		return len(s) >= 1 && len(s) <= 80
+		return 1, 80
 	default:
-		return len(s) >= 2 && len(s) <= 80
+		return 2, 80
 	}
 }
 
-func isValidPart(kind partKind, s string) bool {
-	if !isValidLen(kind, s) {
-		return false
+// validatePart reports whether s is a valid part of the given kind. If it
+// is not, it also returns the [Reason] it failed, for use by
+// [ParseNameErr].
+func validatePart(kind partKind, s string) (Reason, bool) {
+	if s == MissingPart {
+		return ReasonMissingPart, false
+	}
+	if s == "" {
+		return ReasonEmpty, false
+	}
+	if min, max := partLenBounds(kind); len(s) < min {
+		return ReasonTooShort, false
+	} else if len(s) > max {
+		return ReasonTooLong, false
 	}
 	for i := range s {
 		if i == 0 {
 			if !isAlphanumeric(s[i]) {
-				return false
+				return ReasonBadLeadingChar, false
 			}
 			continue
 		}
@@ -293,19 +268,24 @@ func isValidPart(kind partKind, s string) bool {
 		case '_', '-':
 		case '.':
 			if kind == kindNamespace {
-				return false
+				return ReasonBadChar, false
 			}
 		case ':':
 			if kind != kindHost {
-				return false
+				return ReasonBadSeparator, false
 			}
 		default:
 			if !isAlphanumeric(s[i]) {
-				return false
+				return ReasonBadChar, false
 			}
 		}
 	}
-	return true
+	return 0, true
+}
+
+func isValidPart(kind partKind, s string) bool {
+	_, ok := validatePart(kind, s)
+	return ok
 }
 
 func isAlphanumeric(c byte) bool {
@@ -319,86 +299,3 @@ func cutLast(s, sep string) (before, after string, ok bool) {
 	}
 	return s, "", false
 }
-
-// cutPromised cuts the last part of s at the last occurrence of sep. If sep is
-// found, the part before and after sep are returned as-is unless empty, in
-// which case they are returned as MissingPart, which will cause
-// [Name.IsValid] to return false.
-func cutPromised(s, sep string) (before, after string, ok bool) {
-	before, after, ok = cutLast(s, sep)
-	if !ok {
-		return before, after, false
-	}
-	return cmp.Or(before, MissingPart), cmp.Or(after, MissingPart), true
-}
-
-type DigestType int
-
-const (
-	DigestTypeSHA256 DigestType = iota + 1
-)
-
-func (t DigestType) String() string {
-	if t == DigestTypeSHA256 {
-		return "sha256"
-	}
-	return "unknown"
-}
-
-// Digest represents a type and hash of a digest. It is comparable and can
-// be used as a map key.
-type Digest struct {
-	Type DigestType
-	Hash [32]byte
-}
-
-// IsValid returns true if the digest has a valid Type and Hash.
-func (d Digest) IsValid() bool {
-	if d.Type != DigestTypeSHA256 {
-		return false
-	}
-	return d.Hash != [32]byte{}
-}
-
-// String returns the digest as a string in the form "type-hash". The hash
-// is encoded as a hex string.
-func (d Digest) String() string {
-	var b strings.Builder
-	b.WriteString(d.Type.String())
-	b.WriteByte('-')
-	b.WriteString(hex.EncodeToString(d.Hash[:]))
-	return b.String()
-}
-
-// LogValue returns a slog.Value that represents the digest as a string.
-func (d Digest) LogValue() slog.Value {
-	return slog.StringValue(d.String())
-}
-
-// ParseDigest parses a digest string into a Digest struct. It accepts both
-// the forms:
-//
-//	sha256:deadbeef
-//	sha256-deadbeef
-//
-// The hash part must be exactly 64 characters long.
-//
-// The form "type:hash" does not round trip through [Digest.String].
-func ParseDigest(s string) Digest {
-	typ, hash, ok := cutLast(s, ":")
-	if !ok {
-		typ, hash, ok = cutLast(s, "-")
-		if !ok {
-			return Digest{}
-		}
-	}
-	if typ != "sha256" {
-		return Digest{}
-	}
-	var d Digest
-	n, err := hex.Decode(d.Hash[:], []byte(hash))
-	if err != nil || n != 32 {
-		return Digest{}
-	}
-	return Digest{Type: DigestTypeSHA256, Hash: d.Hash}
-}
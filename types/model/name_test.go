@@ -2,7 +2,6 @@ package model
 
 import (
 	"reflect"
-	"strings"
 	"testing"
 )
 
@@ -253,54 +252,3 @@ func FuzzName(f *testing.F) {
 }
 
 const validSHA256Hex = "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789"
-
-func TestParseDigest(t *testing.T) {
-	cases := []struct {
-		in   string
-		want bool
-	}{
-		{in: "sha256:" + validSHA256Hex, want: true},
-		{in: "sha256-" + validSHA256Hex, want: true},
-
-		{in: "", want: false},
-		{in: "sha134:" + validSHA256Hex, want: false},
-		{in: "sha256:" + validSHA256Hex + "x", want: false},
-		{in: "sha256:x" + validSHA256Hex, want: false},
-		{in: "sha256-" + validSHA256Hex + "x", want: false},
-		{in: "sha256-x", want: false},
-	}
-
-	for _, tt := range cases {
-		t.Run(tt.in, func(t *testing.T) {
-			d := ParseDigest(tt.in)
-			if d.IsValid() != tt.want {
-				t.Errorf("ParseDigest(%q).IsValid() = %v; want %v", tt.in, d.IsValid(), tt.want)
-			}
-			norm := strings.ReplaceAll(tt.in, ":", "-")
-			if d.IsValid() && d.String() != norm {
-				t.Errorf("ParseDigest(%q).String() = %q; want %q", tt.in, d.String(), norm)
-			}
-		})
-	}
-}
-
-func TestDigestString(t *testing.T) {
-	cases := []struct {
-		in   string
-		want string
-	}{
-		{in: "sha256:" + validSHA256Hex, want: "sha256-" + validSHA256Hex},
-		{in: "sha256-" + validSHA256Hex, want: "sha256-" + validSHA256Hex},
-		{in: "", want: "unknown-0000000000000000000000000000000000000000000000000000000000000000"},
-		{in: "blah-100000000000000000000000000000000000000000000000000000000000000", want: "unknown-0000000000000000000000000000000000000000000000000000000000000000"},
-	}
-
-	for _, tt := range cases {
-		t.Run(tt.in, func(t *testing.T) {
-			d := ParseDigest(tt.in)
-			if d.String() != tt.want {
-				t.Errorf("ParseDigest(%q).String() = %q; want %q", tt.in, d.String(), tt.want)
-			}
-		})
-	}
-}
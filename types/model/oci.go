@@ -0,0 +1,253 @@
+package model
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Errors returned by [ParseOCIReference] and [Name.OCIReference].
+var (
+	// ErrOCIUppercase is returned when a path component contains an
+	// uppercase letter; the OCI distribution grammar is lowercase-only
+	// for path components (tags may contain uppercase).
+	ErrOCIUppercase = errors.New("oci reference: uppercase not allowed")
+
+	// ErrOCIComponentTooLong is returned when a component of the
+	// reference exceeds the length the OCI grammar allows it.
+	ErrOCIComponentTooLong = errors.New("oci reference: component too long")
+
+	// ErrOCIInvalidDigestAlgorithm is returned when a digest's algorithm
+	// does not match the OCI digest-algorithm grammar, or is not one
+	// [ParseDigestErr] recognizes.
+	ErrOCIInvalidDigestAlgorithm = errors.New("oci reference: invalid digest algorithm")
+
+	// ErrOCIInvalidFormat is returned when the reference does not match
+	// the OCI distribution reference grammar for some other reason,
+	// such as having more path components than a [Name] can represent.
+	ErrOCIInvalidFormat = errors.New("oci reference: invalid format")
+)
+
+// ociPathComponent matches a single '/'-separated component of an OCI
+// reference's path, per the distribution spec's path-component rule.
+// Unlike ollama's namespace part, dots are allowed.
+var ociPathComponent = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*$`)
+
+// ociDigestAlgorithm matches an OCI digest's algorithm component.
+var ociDigestAlgorithm = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*$`)
+
+// ociTag matches an OCI reference's tag, which, unlike ollama's tag part,
+// may start with an underscore.
+var ociTag = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+
+const (
+	// ociPathMaxLen is the maximum length, in bytes, of an OCI
+	// reference's name: the host, if any, and path, excluding the tag
+	// and digest.
+	ociPathMaxLen = 255
+
+	// ociTagMaxLen is the maximum length, in bytes, of an OCI
+	// reference's tag.
+	ociTagMaxLen = 128
+)
+
+// ParseOCIReference parses s as an OCI distribution reference
+// ([domain '/'] path [':' tag] ['@' digest]) and translates it into a
+// [Name].
+//
+// s is split on the last '@' for the digest, then on the last ':'
+// following the last '/' for the tag, so that a "host:port" is not
+// mistaken for a tag. The remaining path is split on '/'; a lone
+// component becomes the model, two components become namespace/model
+// unless the first looks like a domain (it contains '.' or ':', or is
+// "localhost"), and three components are host/namespace/model. Any other
+// number of components is an error, since [Name] has no way to represent
+// it.
+//
+// Path components must be lowercase and match the OCI path-component
+// grammar, which, unlike ollama's namespace part, allows dots. The
+// digest, if present, is stored in n using ollama's "algorithm-hash"
+// form rather than OCI's "algorithm:hash".
+func ParseOCIReference(s string) (Name, error) {
+	var n Name
+
+	s, rawDigest, hasDigest := cutLast(s, "@")
+	if hasDigest {
+		d, err := parseOCIDigest(rawDigest)
+		if err != nil {
+			return Name{}, err
+		}
+		n.rawDigest = d.String()
+	}
+
+	path := s
+	var hasTag bool
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		if j := strings.LastIndex(s[i+1:], ":"); j >= 0 {
+			path, n.tag = s[:i+1+j], s[i+1+j+1:]
+			hasTag = true
+		}
+	} else if j := strings.LastIndex(s, ":"); j >= 0 {
+		path, n.tag = s[:j], s[j+1:]
+		hasTag = true
+	}
+	if hasTag {
+		if n.tag == "" {
+			return Name{}, fmt.Errorf("%w: tag is empty", ErrOCIInvalidFormat)
+		}
+		if err := validateOCITag(n.tag); err != nil {
+			return Name{}, err
+		}
+	}
+
+	if path == "" {
+		return Name{}, fmt.Errorf("%w: missing name", ErrOCIInvalidFormat)
+	}
+	if len(path) > ociPathMaxLen {
+		return Name{}, fmt.Errorf("%w: path %q is longer than %d bytes", ErrOCIComponentTooLong, path, ociPathMaxLen)
+	}
+
+	parts := strings.Split(path, "/")
+	switch len(parts) {
+	case 1:
+		n.model = parts[0]
+	case 2:
+		if looksLikeOCIDomain(parts[0]) {
+			n.host, n.model = parts[0], parts[1]
+		} else {
+			n.namespace, n.model = parts[0], parts[1]
+		}
+	case 3:
+		n.host, n.namespace, n.model = parts[0], parts[1], parts[2]
+	default:
+		return Name{}, fmt.Errorf("%w: %q has too many path components for a model name", ErrOCIInvalidFormat, path)
+	}
+
+	if n.host != "" {
+		if _, ok := validatePart(kindHost, n.host); !ok {
+			return Name{}, fmt.Errorf("%w: host %q", ErrOCIInvalidFormat, n.host)
+		}
+	}
+	if n.namespace != "" {
+		if err := validateOCIPathComponent("namespace", n.namespace); err != nil {
+			return Name{}, err
+		}
+	}
+	if err := validateOCIPathComponent("model", n.model); err != nil {
+		return Name{}, err
+	}
+
+	return n, nil
+}
+
+// OCIReference renders n as an OCI distribution reference string,
+// translating ollama's grammar to the stricter OCI one. It is the
+// inverse of [ParseOCIReference].
+//
+// It returns an error if any path component is not lowercase or does not
+// match the OCI path-component grammar, if the tag does not match the
+// OCI tag grammar, or if n has a digest that [ParseDigestErr] rejects.
+func (n Name) OCIReference() (string, error) {
+	if n.host != "" {
+		if _, ok := validatePart(kindHost, n.host); !ok {
+			return "", fmt.Errorf("%w: host %q", ErrOCIInvalidFormat, n.host)
+		}
+	}
+	if n.namespace != "" {
+		if err := validateOCIPathComponent("namespace", n.namespace); err != nil {
+			return "", err
+		}
+	}
+	if err := validateOCIPathComponent("model", n.model); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if n.host != "" {
+		b.WriteString(n.host)
+		b.WriteByte('/')
+	}
+	if n.namespace != "" {
+		b.WriteString(n.namespace)
+		b.WriteByte('/')
+	}
+	b.WriteString(n.model)
+	if path := b.String(); len(path) > ociPathMaxLen {
+		return "", fmt.Errorf("%w: path %q is longer than %d bytes", ErrOCIComponentTooLong, path, ociPathMaxLen)
+	}
+
+	if n.tag != "" {
+		if err := validateOCITag(n.tag); err != nil {
+			return "", err
+		}
+		b.WriteByte(':')
+		b.WriteString(n.tag)
+	}
+
+	if n.rawDigest != "" {
+		d, err := ParseDigestErr(n.rawDigest)
+		if err != nil {
+			if errors.Is(err, ErrDigestUnsupported) {
+				return "", fmt.Errorf("%w: %v", ErrOCIInvalidDigestAlgorithm, err)
+			}
+			return "", fmt.Errorf("%w: %v", ErrOCIInvalidFormat, err)
+		}
+		b.WriteByte('@')
+		b.WriteString(d.Algorithm)
+		b.WriteByte(':')
+		b.WriteString(hex.EncodeToString(d.Hash))
+	}
+
+	return b.String(), nil
+}
+
+// looksLikeOCIDomain reports whether s should be treated as a domain
+// rather than a namespace when a two-component OCI path is split, the
+// same heuristic used by the distribution reference parser: it is a
+// domain if it contains a '.' or ':', or is exactly "localhost".
+func looksLikeOCIDomain(s string) bool {
+	return s == "localhost" || strings.ContainsAny(s, ".:")
+}
+
+func validateOCIPathComponent(what, s string) error {
+	if s == "" {
+		return fmt.Errorf("%w: %s is empty", ErrOCIInvalidFormat, what)
+	}
+	if strings.ToLower(s) != s {
+		return fmt.Errorf("%w: %s %q", ErrOCIUppercase, what, s)
+	}
+	if !ociPathComponent.MatchString(s) {
+		return fmt.Errorf("%w: %s %q", ErrOCIInvalidFormat, what, s)
+	}
+	return nil
+}
+
+func validateOCITag(tag string) error {
+	if len(tag) > ociTagMaxLen {
+		return fmt.Errorf("%w: tag %q is longer than %d bytes", ErrOCIComponentTooLong, tag, ociTagMaxLen)
+	}
+	if !ociTag.MatchString(tag) {
+		return fmt.Errorf("%w: tag %q", ErrOCIInvalidFormat, tag)
+	}
+	return nil
+}
+
+// parseOCIDigest parses s ("algorithm:hash") using the same algorithm
+// table as [ParseDigestErr], additionally checking the algorithm against
+// the OCI digest-algorithm grammar.
+func parseOCIDigest(s string) (Digest, error) {
+	algo, _, ok := cutLast(s, ":")
+	if !ok || !ociDigestAlgorithm.MatchString(algo) {
+		return Digest{}, fmt.Errorf("%w: %q", ErrOCIInvalidDigestAlgorithm, s)
+	}
+	d, err := ParseDigestErr(s)
+	if err != nil {
+		if errors.Is(err, ErrDigestUnsupported) {
+			return Digest{}, fmt.Errorf("%w: %v", ErrOCIInvalidDigestAlgorithm, err)
+		}
+		return Digest{}, fmt.Errorf("%w: %v", ErrOCIInvalidFormat, err)
+	}
+	return d, nil
+}
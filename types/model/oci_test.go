@@ -0,0 +1,111 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOCIReferenceRoundTrip(t *testing.T) {
+	cases := []string{
+		// valid in both schemes
+		"model",
+		"namespace/model",
+		"registry.example.com/namespace/model",
+		"registry.example.com/namespace/model:latest",
+		"localhost:5000/model",
+		"localhost:5000/namespace/model:tag",
+
+		// valid only in OCI: dots in the namespace component, which
+		// ollama's own namespace part disallows.
+		"registry.example.com/my.namespace/model:tag",
+	}
+
+	for _, s := range cases {
+		t.Run(s, func(t *testing.T) {
+			n, err := ParseOCIReference(s)
+			if err != nil {
+				t.Fatalf("ParseOCIReference(%q) = %v", s, err)
+			}
+			got, err := n.OCIReference()
+			if err != nil {
+				t.Fatalf("OCIReference() = %v", err)
+			}
+			if got != s {
+				t.Errorf("OCIReference() = %q; want %q", got, s)
+			}
+		})
+	}
+}
+
+func TestParseOCIReferenceUppercaseTag(t *testing.T) {
+	// Unlike path components, OCI tags may contain uppercase letters.
+	n, err := ParseOCIReference("model:V1.0-RC1")
+	if err != nil {
+		t.Fatalf("ParseOCIReference() = %v", err)
+	}
+	if n.Tag() != "V1.0-RC1" {
+		t.Errorf("Tag() = %q; want %q", n.Tag(), "V1.0-RC1")
+	}
+}
+
+func TestParseOCIReferenceTrailingColon(t *testing.T) {
+	// A trailing ':' with no tag content is a parse error, not a
+	// silently-dropped tag.
+	if _, err := ParseOCIReference("model:"); !errors.Is(err, ErrOCIInvalidFormat) {
+		t.Errorf("ParseOCIReference(%q) error = %v; want ErrOCIInvalidFormat", "model:", err)
+	}
+}
+
+func TestOCIReferenceOnlyValidInOllamaScheme(t *testing.T) {
+	// Uppercase is allowed by ollama's grammar but not OCI's.
+	n := ParseName("Namespace/Model:Tag")
+	if !n.IsValid() {
+		t.Fatalf("ParseName(%q) produced invalid name", "Namespace/Model:Tag")
+	}
+	if _, err := n.OCIReference(); !errors.Is(err, ErrOCIUppercase) {
+		t.Errorf("OCIReference() error = %v; want ErrOCIUppercase", err)
+	}
+}
+
+func TestParseOCIReferenceErrors(t *testing.T) {
+	cases := []struct {
+		in      string
+		wantErr error
+	}{
+		{in: "Namespace/Model", wantErr: ErrOCIUppercase},
+		{in: "a/b/c/d", wantErr: ErrOCIInvalidFormat},
+		{in: "", wantErr: ErrOCIInvalidFormat},
+		{in: "model@sha1:" + validSHA256Hex, wantErr: ErrOCIInvalidDigestAlgorithm},
+		{in: "model@sha256:nothex", wantErr: ErrOCIInvalidFormat},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParseOCIReference(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseOCIReference(%q) error = %v; want %v", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseOCIReferenceWithDigest(t *testing.T) {
+	n, err := ParseOCIReference("model@sha256:" + validSHA256Hex)
+	if err != nil {
+		t.Fatalf("ParseOCIReference() = %v", err)
+	}
+	if want := "sha256-" + validSHA256Hex; n.RawDigest() != want {
+		t.Errorf("RawDigest() = %q; want %q", n.RawDigest(), want)
+	}
+	if !n.Digest().IsValid() {
+		t.Errorf("Digest().IsValid() = false")
+	}
+
+	got, err := n.OCIReference()
+	if err != nil {
+		t.Fatalf("OCIReference() = %v", err)
+	}
+	if want := "model@sha256:" + validSHA256Hex; got != want {
+		t.Errorf("OCIReference() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// Reason is a machine-checkable code describing why a [Name] part failed
+// to validate. Callers that want to build their own error message, rather
+// than use [ParseError.Error], can switch on it.
+type Reason int
+
+const (
+	ReasonEmpty Reason = iota + 1
+	ReasonTooShort
+	ReasonTooLong
+	ReasonBadLeadingChar
+	ReasonBadChar
+	ReasonBadSeparator
+	ReasonMissingPart
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonEmpty:
+		return "must not be empty"
+	case ReasonTooShort:
+		return "is too short"
+	case ReasonTooLong:
+		return "is too long"
+	case ReasonBadLeadingChar:
+		return "must start with alphanumeric"
+	case ReasonBadChar:
+		return "contains an invalid character"
+	case ReasonBadSeparator:
+		return "contains a separator character that is not allowed here"
+	case ReasonMissingPart:
+		return "is missing"
+	default:
+		return "is invalid"
+	}
+}
+
+// ParseError is returned by [ParseNameErr] when a name string fails to
+// parse into a valid [Name]. It identifies the offending part, its
+// substring, its byte offset within the original input, and a [Reason]
+// code.
+type ParseError struct {
+	Name   string
+	Kind   partKind
+	Part   string
+	Offset int
+	Reason Reason
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid name %q: %s part %s at offset %d", e.Name, e.Kind, e.Reason, e.Offset)
+}
+
+// ParseNameErr parses s the same as [ParseNameNoDefaults], but returns a
+// *[ParseError] describing the first invalid part, instead of requiring
+// the caller to separately check [Name.IsValid].
+func ParseNameErr(s string) (Name, error) {
+	n, offsets := parseNameOffsets(s)
+	kind, part, reason, ok := checkName(n)
+	if !ok {
+		return n, &ParseError{Name: s, Kind: kind, Part: part, Offset: offsets[kind], Reason: reason}
+	}
+	return n, nil
+}
+
+// checkName reports the first part of n that fails validation, along with
+// why, in the same host/namespace/model/tag/digest order as [Name.String].
+// ok is true if n is valid.
+func checkName(n Name) (kind partKind, part string, reason Reason, ok bool) {
+	if n.model == "" && n.rawDigest == "" {
+		return kindModel, n.model, ReasonEmpty, false
+	}
+	parts := [...]string{n.host, n.namespace, n.model, n.tag, n.rawDigest}
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		if r, valid := validatePart(partKind(i), p); !valid {
+			return partKind(i), p, r, false
+		}
+	}
+	return 0, "", 0, true
+}
+
+// parseNameOffsets parses s into a Name exactly as [ParseNameNoDefaults]
+// does, additionally recording the byte offset of each part within s, for
+// use by [ParseNameErr]. The offsets are indexed by partKind, and are
+// meaningless for any part that was never reached (e.g. host and
+// namespace when s has no '/').
+func parseNameOffsets(s string) (Name, [5]int) {
+	var n Name
+	var offs [5]int
+	var ok bool
+
+	// Digest is the exception to the rule that both parts separated by a
+	// separator must be present. If the digest is promised, the digest
+	// part must be present, but the name part can be empty/undefined.
+	var offset int
+	s, n.rawDigest, offset, ok = cutLastAt(s, "@")
+	if ok {
+		offs[kindDigest] = offset
+		if n.rawDigest == "" {
+			n.rawDigest = MissingPart
+		}
+	}
+
+	s, n.tag, offs[kindTag], _ = cutPromisedAt(s, ":")
+
+	var promised bool
+	s, n.model, offs[kindModel], promised = cutPromisedAt(s, "/")
+	if !promised {
+		n.model = s
+		return n, offs
+	}
+
+	s, n.namespace, offs[kindNamespace], promised = cutPromisedAt(s, "/")
+	if !promised {
+		n.namespace = s
+		return n, offs
+	}
+	n.host = s
+
+	return n, offs
+}
+
+// cutLastAt is like [cutLast], but additionally returns the byte offset
+// of after within s. If sep is not found, after is never reached by a
+// separator and so starts at the beginning of s; offset is 0, not a
+// sentinel, since s itself becomes that part's value.
+func cutLastAt(s, sep string) (before, after string, offset int, ok bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", 0, false
+	}
+	return s[:i], s[i+len(sep):], i + len(sep), true
+}
+
+// cutPromisedAt is like [cutLastAt], but substitutes MissingPart for
+// before or after if either is empty, so that a part promised by a
+// separator but missing fails validation.
+func cutPromisedAt(s, sep string) (before, after string, offset int, ok bool) {
+	before, after, offset, ok = cutLastAt(s, sep)
+	if !ok {
+		return before, after, offset, false
+	}
+	return cmp.Or(before, MissingPart), cmp.Or(after, MissingPart), offset, true
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseNameErr(t *testing.T) {
+	cases := []struct {
+		in         string
+		wantKind   partKind
+		wantReason Reason
+		wantOffset int
+		wantMsg    string
+	}{
+		{
+			in:         "HH/-nn/mm",
+			wantKind:   kindNamespace,
+			wantReason: ReasonBadLeadingChar,
+			wantOffset: 3,
+			wantMsg:    `invalid name "HH/-nn/mm": namespace part must start with alphanumeric at offset 3`,
+		},
+		{
+			in:         "m",
+			wantKind:   kindModel,
+			wantReason: ReasonTooShort,
+		},
+		{
+			in:         "hh/nn/mm:",
+			wantKind:   kindTag,
+			wantReason: ReasonMissingPart,
+		},
+		{
+			in:         "",
+			wantKind:   kindModel,
+			wantReason: ReasonEmpty,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := ParseNameErr(tt.in)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseNameErr(%q) error = %v (%T); want *ParseError", tt.in, err, err)
+			}
+			if perr.Kind != tt.wantKind {
+				t.Errorf("Kind = %v; want %v", perr.Kind, tt.wantKind)
+			}
+			if perr.Reason != tt.wantReason {
+				t.Errorf("Reason = %v; want %v", perr.Reason, tt.wantReason)
+			}
+			if tt.wantOffset != 0 && perr.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d; want %d", perr.Offset, tt.wantOffset)
+			}
+			if tt.wantMsg != "" && perr.Error() != tt.wantMsg {
+				t.Errorf("Error() = %q; want %q", perr.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseNameErrValid(t *testing.T) {
+	_, err := ParseNameErr("host/namespace/model:tag")
+	if err != nil {
+		t.Errorf("ParseNameErr() = %v; want nil", err)
+	}
+}
+
+// TestParseNameErrOffsetUnreachedPart checks that a part never reached via
+// a separator (the common case of a bare model name) reports offset 0, the
+// start of the input.
+func TestParseNameErrOffsetUnreachedPart(t *testing.T) {
+	cases := []string{"m", "bad*char"}
+	for _, in := range cases {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseNameErr(in)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ParseNameErr(%q) error = %v (%T); want *ParseError", in, err, err)
+			}
+			if perr.Offset != 0 {
+				t.Errorf("Offset = %d; want 0", perr.Offset)
+			}
+		})
+	}
+}
+
+func TestParseNameErrAgreesWithIsValid(t *testing.T) {
+	for s, want := range testCases {
+		n, err := ParseNameErr(s)
+		got := err == nil
+		if got != want {
+			t.Errorf("ParseNameErr(%q) valid = %v (err=%v); want %v", s, got, err, want)
+		}
+		if got != n.IsValid() {
+			t.Errorf("ParseNameErr(%q) == nil (%v) disagrees with IsValid() (%v)", s, got, n.IsValid())
+		}
+	}
+}